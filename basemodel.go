@@ -0,0 +1,20 @@
+package gormuuid
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// A BaseModel is a drop-in replacement for gorm.Model that uses a UUID primary key instead of an auto-incrementing uint. Embed it the same way you would gorm.Model or UUID:
+//
+//    type Person struct {
+//        gormuuid.BaseModel
+//        Name string
+//    }
+type BaseModel struct {
+	UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
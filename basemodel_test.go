@@ -0,0 +1,92 @@
+package gormuuid_test
+
+import (
+	"testing"
+
+	"github.com/exlibris-fed/gormuuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type person struct {
+	gormuuid.BaseModel
+	Name string
+}
+
+type taggedPerson struct {
+	gormuuid.BaseModel `gormuuid:"v7"`
+	Name               string
+}
+
+func openBaseModelTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&person{}, &taggedPerson{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func TestBaseModelGeneratesID(t *testing.T) {
+	db := openBaseModelTestDB(t)
+
+	p := person{Name: "Frank"}
+	if err := db.Create(&p).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if len(p.ID) != 16 {
+		t.Fatalf("expected a 16-byte ID after create, got %d bytes", len(p.ID))
+	}
+	if p.CreatedAt.IsZero() {
+		t.Fatal("expected CreatedAt to be set after create")
+	}
+}
+
+func TestBaseModelSoftDelete(t *testing.T) {
+	db := openBaseModelTestDB(t)
+
+	p := person{Name: "Frank"}
+	if err := db.Create(&p).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if err := db.Delete(&p).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	var found person
+	err := db.Where("name = ?", "Frank").First(&found).Error
+	if err != gorm.ErrRecordNotFound {
+		t.Fatalf("expected a soft-deleted row to be excluded by default, got %v", err)
+	}
+
+	var withDeleted person
+	if err := db.Unscoped().Where("name = ?", "Frank").First(&withDeleted).Error; err != nil {
+		t.Fatalf("unscoped find: %v", err)
+	}
+	if withDeleted.DeletedAt.Time.IsZero() {
+		t.Fatal("expected DeletedAt to be set on the soft-deleted row")
+	}
+}
+
+func TestBaseModelGeneratorTag(t *testing.T) {
+	db := openBaseModelTestDB(t)
+
+	p := taggedPerson{Name: "Frank"}
+	if err := db.Create(&p).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	id, err := p.UUID.UUID()
+	if err != nil {
+		t.Fatalf("UUID: %v", err)
+	}
+	if id.Version() != 7 {
+		t.Fatalf("expected a v7 ID via BaseModel's gormuuid tag, got version %v", id.Version())
+	}
+}
@@ -0,0 +1,48 @@
+package gormuuid
+
+import (
+	"github.com/google/uuid"
+)
+
+// A Generator produces a new UUID to assign to a model's ID field on creation.
+type Generator func() (uuid.UUID, error)
+
+// DefaultGenerator is the Generator used by BeforeCreate when a model hasn't requested another version via SetGenerator or the "gormuuid" struct tag. It produces a random (v4) UUID, matching this package's original behaviour.
+var DefaultGenerator Generator = func() (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+
+// V1Generator returns a Generator that produces time-and-MAC-address-based (v1) UUIDs.
+func V1Generator() Generator {
+	return func() (uuid.UUID, error) {
+		return uuid.NewUUID()
+	}
+}
+
+// V5Generator returns a Generator that produces namespace-and-name-based (v5) UUIDs. Because a Generator takes no arguments, nameFn is called with nil; construct the Generator inside the model's own BeforeCreate hook, where nameFn can close over the model instead, eg:
+//
+//    func (p *Person) BeforeCreate(tx *gorm.DB) error {
+//        p.SetGenerator(gormuuid.V5Generator(myNamespace, func(interface{}) string {
+//            return p.Name
+//        }))
+//        return p.UUID.BeforeCreate(tx)
+//    }
+func V5Generator(namespace uuid.UUID, nameFn func(interface{}) string) Generator {
+	return func() (uuid.UUID, error) {
+		return uuid.NewSHA1(namespace, []byte(nameFn(nil))), nil
+	}
+}
+
+// V6Generator returns a Generator that produces reordered-time-based (v6) UUIDs.
+func V6Generator() Generator {
+	return func() (uuid.UUID, error) {
+		return uuid.NewV6()
+	}
+}
+
+// V7Generator returns a Generator that produces Unix-epoch-time-ordered (v7) UUIDs. Because v7 UUIDs sort close to insertion order, they give much better B-tree index locality than v4 when used as a primary key.
+func V7Generator() Generator {
+	return func() (uuid.UUID, error) {
+		return uuid.NewV7()
+	}
+}
@@ -0,0 +1,78 @@
+package gormuuid_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/exlibris-fed/gormuuid"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type scopedWidget struct {
+	gormuuid.ScopedUUID
+	TenantID uuid.UUID
+	Name     string
+}
+
+func TestScopedUUIDSharesTenantPrefix(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&scopedWidget{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	tenantA := uuid.New()
+	tenantB := uuid.New()
+
+	create := func(tenant uuid.UUID, name string) scopedWidget {
+		w := scopedWidget{TenantID: tenant, Name: name}
+		tx := db.WithContext(gormuuid.WithScope(context.Background(), tenant))
+		if err := tx.Create(&w).Error; err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		return w
+	}
+
+	a1 := create(tenantA, "a1")
+	a2 := create(tenantA, "a2")
+	b1 := create(tenantB, "b1")
+
+	idA1, _ := a1.UUID.UUID()
+	idA2, _ := a2.UUID.UUID()
+	idB1, _ := b1.UUID.UUID()
+
+	const prefixLen = 6
+	if !bytes.Equal(idA1[:prefixLen], idA2[:prefixLen]) {
+		t.Fatalf("expected rows in the same tenant to share a prefix: %s vs %s", idA1, idA2)
+	}
+	if bytes.Equal(idA1[:prefixLen], idB1[:prefixLen]) {
+		t.Fatalf("expected rows in different tenants not to share a prefix: %s vs %s", idA1, idB1)
+	}
+	if idA1 == idA2 {
+		t.Fatalf("expected two rows in the same tenant to still get distinct IDs")
+	}
+}
+
+func TestScopedUUIDFallsBackWithoutScope(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&scopedWidget{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	w := scopedWidget{Name: "unscoped"}
+	if err := db.Create(&w).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if len(w.ID) != 16 {
+		t.Fatalf("expected a 16-byte ID even without a scope, got %d bytes", len(w.ID))
+	}
+}
@@ -0,0 +1,178 @@
+package gormuuid
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// shortIDAlphabet is the 57-character alphabet used to encode a ShortID, chosen to exclude visually ambiguous characters (0, O, I, 1, l).
+const shortIDAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// shortIDLength is the fixed width of an encoded ShortID: ceil(log57(2^128)).
+const shortIDLength = 22
+
+// ErrorInvalidShortID is returned when decoding a string that isn't a well-formed ShortID.
+var ErrorInvalidShortID = errors.New("gormuuid: invalid ShortID")
+
+// A ShortID is a UUID primary key whose on-the-wire form is a fixed-width, 22-character base57 string (eg "KwSysDpxcBU9FNhGkn2dCf") while the database column still stores the canonical 16 bytes of the UUID.
+//
+// Unlike UUID, which is meant to be embedded anonymously so that its hooks are promoted onto the model, ShortID is a plain value type: assign it to a named field the way you would a uuid.UUID, and generate one with NewShortID. Embedding it anonymously would also promote its MarshalJSON method onto the model, which would break JSON encoding of any sibling fields.
+//
+//    type Person struct {
+//        ID gormuuid.ShortID `gorm:"primary key"`
+//        Name string
+//    }
+//
+//    p := Person{ID: gormuuid.NewShortID(), Name: "Frank"}
+type ShortID uuid.UUID
+
+// NewShortID generates a new, random ShortID.
+func NewShortID() ShortID {
+	return ShortID(uuid.New())
+}
+
+// String returns the base57-encoded form of the ID.
+func (s ShortID) String() string {
+	return Encode(uuid.UUID(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ShortID) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *ShortID) UnmarshalText(text []byte) error {
+	id, err := Decode(string(text))
+	if err != nil {
+		return err
+	}
+	*s = ShortID(id)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ShortID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ShortID) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(str))
+}
+
+// Value implements driver.Valuer. It's only used outside of GORM (eg a raw database/sql call); within GORM, GormValue below takes precedence so the value is written in whatever form the dialect's native column actually expects.
+func (s ShortID) Value() (driver.Value, error) {
+	return uuid.UUID(s).MarshalBinary()
+}
+
+// GormValue implements gorm.Valuer the same way UUID.GormValue does: the canonical dashed string for dialects with a native uuid/uniqueidentifier column, or raw 16 bytes otherwise. See UUID.GormValue for why.
+func (s ShortID) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if nativeUUIDDialects[db.Dialector.Name()] {
+		return clause.Expr{SQL: "?", Vars: []interface{}{uuid.UUID(s).String()}}
+	}
+
+	b, _ := uuid.UUID(s).MarshalBinary()
+	return clause.Expr{SQL: "?", Vars: []interface{}{b}}
+}
+
+// Scan implements sql.Scanner. Drivers for dialects with a native uuid type (eg postgres via pgx) commonly scan such a column into a string rather than raw bytes; Scan accepts either form so the ID round-trips correctly regardless of dialect.
+func (s *ShortID) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			id, err := uuid.FromBytes(v)
+			if err != nil {
+				return err
+			}
+			*s = ShortID(id)
+			return nil
+		}
+		return s.Scan(string(v))
+	case string:
+		if v == "" {
+			return nil
+		}
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return fmt.Errorf("gormuuid: cannot scan %q into ShortID: %w", v, err)
+		}
+		*s = ShortID(id)
+		return nil
+	default:
+		return fmt.Errorf("gormuuid: cannot scan %T into ShortID", value)
+	}
+}
+
+// GormDBDataType reports the same dialect-aware column type as UUID, since a ShortID stores the same 16 bytes.
+func (ShortID) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return UUID{}.GormDBDataType(db, field)
+}
+
+// Encode converts a uuid.UUID into its fixed-width, 22-character base57 representation.
+func Encode(id uuid.UUID) string {
+	n := new(big.Int).SetBytes(id[:])
+	base := big.NewInt(int64(len(shortIDAlphabet)))
+
+	var digits []byte
+	for n.Sign() > 0 {
+		mod := new(big.Int)
+		n.DivMod(n, base, mod)
+		digits = append(digits, shortIDAlphabet[mod.Int64()])
+	}
+	for len(digits) < shortIDLength {
+		digits = append(digits, shortIDAlphabet[0])
+	}
+
+	// digits was built least-significant-first; reverse it into normal reading order.
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+
+	return string(digits)
+}
+
+// Decode parses a base57 ShortID string, as produced by Encode, back into a uuid.UUID.
+func Decode(s string) (uuid.UUID, error) {
+	if len(s) != shortIDLength {
+		return uuid.UUID{}, ErrorInvalidShortID
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(shortIDAlphabet)))
+	for _, c := range s {
+		idx := strings.IndexRune(shortIDAlphabet, c)
+		if idx < 0 {
+			return uuid.UUID{}, ErrorInvalidShortID
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	b := n.Bytes()
+	if len(b) > 16 {
+		return uuid.UUID{}, ErrorInvalidShortID
+	}
+
+	var id uuid.UUID
+	copy(id[16-len(b):], b)
+	return id, nil
+}
@@ -0,0 +1,93 @@
+package gormuuid_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/exlibris-fed/gormuuid"
+	"github.com/google/uuid"
+)
+
+func TestShortIDEncodeDecodeRoundTrip(t *testing.T) {
+	cases := []uuid.UUID{
+		{},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		var id uuid.UUID
+		r.Read(id[:])
+		cases = append(cases, id)
+	}
+
+	for _, id := range cases {
+		s := gormuuid.Encode(id)
+		if len(s) != 22 {
+			t.Fatalf("Encode(%v): expected 22 chars, got %d (%q)", id, len(s), s)
+		}
+
+		back, err := gormuuid.Decode(s)
+		if err != nil {
+			t.Fatalf("Decode(%q): %v", s, err)
+		}
+		if back != id {
+			t.Fatalf("round trip mismatch: Encode(%v) = %q, Decode gave %v", id, s, back)
+		}
+	}
+}
+
+func TestDecodeRejectsInvalidInput(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"tooshort",
+		"012345678901234567890123456789", // wrong length
+		"0000000000000000000!0000000",    // invalid character, wrong length
+	} {
+		if _, err := gormuuid.Decode(s); err == nil {
+			t.Fatalf("Decode(%q): expected an error", s)
+		}
+	}
+}
+
+func TestShortIDJSONRoundTrip(t *testing.T) {
+	id := gormuuid.NewShortID()
+
+	data, err := id.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var back gormuuid.ShortID
+	if err := back.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	if back != id {
+		t.Fatalf("JSON round trip mismatch: %v != %v", back, id)
+	}
+}
+
+func TestShortIDScanAcceptsStringAndBytes(t *testing.T) {
+	id := gormuuid.NewShortID()
+	raw, err := id.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var fromBytes gormuuid.ShortID
+	if err := fromBytes.Scan(raw); err != nil {
+		t.Fatalf("scan bytes: %v", err)
+	}
+	if fromBytes != id {
+		t.Fatalf("scan bytes round trip: got %v, want %v", fromBytes, id)
+	}
+
+	var fromString gormuuid.ShortID
+	if err := fromString.Scan(uuid.UUID(id).String()); err != nil {
+		t.Fatalf("scan string: %v", err)
+	}
+	if fromString != id {
+		t.Fatalf("scan string round trip: got %v, want %v", fromString, id)
+	}
+}
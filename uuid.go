@@ -15,39 +15,217 @@
 //        Name string
 //        // etc
 //    }
+//
+// By default IDs are generated as v4 (random) UUIDs. To use a different version, tag the embedded UUID field itself (eg `gormuuid:"v7"` for time-ordered, index-friendly UUIDs) or call SetGenerator with one of V1Generator, V5Generator, V6Generator or V7Generator before the model is created.
 package gormuuid
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"reflect"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
+// uuidType is used to recognise the embedded UUID field on a model when resolving a per-model "gormuuid" struct tag.
+var uuidType = reflect.TypeOf(UUID{})
+
 var (
 	// ErrorNoUUID is the error returned when you attempt to get a UUID when it hasn't been generated.
 	ErrorNoUUID = errors.New("UUID has not been created")
+
+	// ErrorZeroUUID is the error returned when a model is saved or updated with a zero-valued ID.
+	ErrorZeroUUID = errors.New("UUID is zero-valued")
 )
 
 // A UUID is a struct that can be embedded to add UUID primary key support to a GORM model.
 type UUID struct {
 	ID []byte `gorm:"primary key"`
+
+	generator Generator `gorm:"-"`
+}
+
+// SetGenerator overrides the Generator this model uses to create its ID, in place of DefaultGenerator or a "gormuuid" struct tag. Call it before BeforeCreate runs, eg from the model's own BeforeCreate hook.
+func (u *UUID) SetGenerator(g Generator) {
+	u.generator = g
+}
+
+// resolveGenerator picks the Generator to use for this model: an override set via SetGenerator, then the version named by a `gormuuid:"..."` tag on the model's embedded UUID field, then DefaultGenerator.
+func (u *UUID) resolveGenerator(tx *gorm.DB) Generator {
+	if u.generator != nil {
+		return u.generator
+	}
+
+	if g := generatorFromTag(tx); g != nil {
+		return g
+	}
+
+	return DefaultGenerator
+}
+
+// generatorFromTag inspects the model being created for a `gormuuid:"..."` tag on the anonymous field that embeds UUID (directly, as on UUID itself, or indirectly, as on BaseModel or ScopedUUID) and returns the matching Generator, or nil if there is none.
+func generatorFromTag(tx *gorm.DB) Generator {
+	if tx == nil || tx.Statement == nil {
+		return nil
+	}
+
+	v := modelValue(tx)
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.Anonymous || !embedsUUID(f.Type) {
+			continue
+		}
+
+		switch f.Tag.Get("gormuuid") {
+		case "v1":
+			return V1Generator()
+		case "v6":
+			return V6Generator()
+		case "v7":
+			return V7Generator()
+		}
+	}
+
+	return nil
+}
+
+// embedsUUID reports whether t is UUID itself, or a struct that embeds UUID anonymously at some depth (directly, as BaseModel and ScopedUUID do, or through another such type).
+func embedsUUID(t reflect.Type) bool {
+	if t == uuidType {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Anonymous && embedsUUID(f.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modelValue returns the struct value being created, unwrapping pointers and, for batch inserts, indexing into the destination slice with the row currently being processed.
+func modelValue(tx *gorm.DB) reflect.Value {
+	v := tx.Statement.ReflectValue
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		if tx.Statement.CurDestIndex < 0 || tx.Statement.CurDestIndex >= v.Len() {
+			return reflect.Value{}
+		}
+		v = v.Index(tx.Statement.CurDestIndex)
+		for v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+	}
+
+	return v
+}
+
+// nativeUUIDDialects are the dialects GormDBDataType gives a real uuid/uniqueidentifier column, as opposed to a fixed-width binary one; GormValue uses the same set to decide whether to hand the driver text or raw bytes.
+var nativeUUIDDialects = map[string]bool{
+	"postgres":    true,
+	"cockroachdb": true,
+	"sqlserver":   true,
+}
+
+// GormDBDataType tells GORM what column type to use for the ID field on each supported dialect, so that databases with a native UUID type don't get stuck with a generic blob.
+func (UUID) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres", "cockroachdb":
+		return "uuid"
+	case "mysql":
+		return "BINARY(16)"
+	case "sqlserver":
+		return "uniqueidentifier"
+	default:
+		return "blob"
+	}
+}
+
+// GormValue implements gorm.Valuer so the ID is written in whatever form its column actually expects: the canonical dashed string for dialects with a native uuid/uniqueidentifier type (this is also what avoids go-mssqldb's mixed-endian byte layout, since it never touches raw bytes on sqlserver), or the raw 16 bytes for the fixed-width binary/blob columns GormDBDataType uses elsewhere.
+func (u UUID) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if len(u.ID) == 16 && nativeUUIDDialects[db.Dialector.Name()] {
+		if id, err := uuid.FromBytes(u.ID); err == nil {
+			return clause.Expr{SQL: "?", Vars: []interface{}{id.String()}}
+		}
+	}
+
+	return clause.Expr{SQL: "?", Vars: []interface{}{[]byte(u.ID)}}
+}
+
+// Scan implements sql.Scanner. Drivers for dialects with a native uuid type (eg postgres via pgx) commonly scan such a column into a string rather than raw bytes; Scan accepts either form so the ID round-trips correctly regardless of dialect.
+func (u *UUID) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		if len(v) == 16 {
+			u.ID = append([]byte(nil), v...)
+			return nil
+		}
+		return u.Scan(string(v))
+	case string:
+		if v == "" {
+			return nil
+		}
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return fmt.Errorf("gormuuid: cannot scan %q into UUID: %w", v, err)
+		}
+		u.ID = id[:]
+		return nil
+	default:
+		return fmt.Errorf("gormuuid: cannot scan %T into UUID", value)
+	}
 }
 
 // BeforeCreate ensures that a model has a valid UUID before insertion into the database. If one exists already (ie your implementation needed to specify one) it will be respected.
-func (u *UUID) BeforeCreate() (err error) {
+func (u *UUID) BeforeCreate(tx *gorm.DB) (err error) {
 	if len(u.ID) == 16 {
 		return
 	}
 
-	uuid, err := uuid.New().MarshalBinary()
+	id, err := u.resolveGenerator(tx)()
+	if err != nil {
+		return
+	}
+
+	b, err := id.MarshalBinary()
 	if err != nil {
 		return
 	}
 
-	u.ID = uuid
+	u.ID = b
 	return
 }
 
+// BeforeUpdate rejects a zero-valued ID, catching the case where a model is updated after its ID was cleared out from under it.
+//
+// There is deliberately no BeforeSave here: GORM calls BeforeSave ahead of both BeforeCreate and BeforeUpdate on the *same* call, for creates and updates alike, so a BeforeSave that filled in a missing ID would paper over exactly the zero-ID updates this guard exists to catch, and a BeforeSave that rejected one would reject every legitimate create (whose ID is correctly still empty at that point). Note this guard can only see updates that actually reach GORM's update callback chain: db.Save on a struct with a zero primary key is routed to Create by GORM itself, before any hook runs, which is GORM's own create-vs-update heuristic rather than something a hook can override.
+func (u *UUID) BeforeUpdate(tx *gorm.DB) error {
+	if len(u.ID) != 16 {
+		return ErrorZeroUUID
+	}
+	return nil
+}
+
 // UUID returns the UUID of the model's ID.
 //
 // As it returns a uuid.UUID object, you can then call any of that package's methods. (see https://godoc.org/github.com/google/uuid). For example, to use as a string:
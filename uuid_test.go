@@ -0,0 +1,190 @@
+package gormuuid_test
+
+import (
+	"testing"
+
+	"github.com/exlibris-fed/gormuuid"
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type widget struct {
+	gormuuid.UUID
+	Name string
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&widget{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+	return db
+}
+
+func TestBeforeCreateGeneratesID(t *testing.T) {
+	db := openTestDB(t)
+
+	w := widget{Name: "thing"}
+	if err := db.Create(&w).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	if len(w.ID) != 16 {
+		t.Fatalf("expected a 16-byte ID after create, got %d bytes", len(w.ID))
+	}
+}
+
+func TestBeforeUpdateRejectsZeroID(t *testing.T) {
+	db := openTestDB(t)
+
+	w := widget{Name: "thing"}
+	if err := db.Create(&w).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	w.ID = nil
+	w.Name = "renamed"
+
+	// A direct Model().Updates() call (as opposed to Save()) goes through
+	// GORM's update callback chain even when the struct's ID has been
+	// zeroed out, so BeforeUpdate's guard should have a chance to fire
+	// and reject it rather than silently doing nothing.
+	err := db.Model(&widget{}).Where("name = ?", "thing").Updates(&w).Error
+	if err != gormuuid.ErrorZeroUUID {
+		t.Fatalf("expected ErrorZeroUUID, got %v", err)
+	}
+}
+
+func TestSaveWithZeroIDCreatesANewRowByGORMDesign(t *testing.T) {
+	db := openTestDB(t)
+
+	w := widget{Name: "thing"}
+	if err := db.Create(&w).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// This documents GORM's own Save() semantics: a zero primary key is
+	// treated as "this is a new record" before any hook ever runs, so
+	// Save() here creates a second row rather than updating the first.
+	// No hook can intercept this, since the routing decision happens in
+	// GORM's finisher before BeforeSave/BeforeCreate/BeforeUpdate do.
+	w.ID = nil
+	w.Name = "renamed"
+	if err := db.Save(&w).Error; err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	var count int64
+	db.Model(&widget{}).Count(&count)
+	if count != 2 {
+		t.Fatalf("expected 2 rows (GORM routed the zero-ID Save to Create), got %d", count)
+	}
+}
+
+func TestUUIDScanAcceptsStringAndBytes(t *testing.T) {
+	id := uuid.New()
+	raw, _ := id.MarshalBinary()
+
+	var fromBytes gormuuid.UUID
+	if err := fromBytes.Scan(raw); err != nil {
+		t.Fatalf("scan bytes: %v", err)
+	}
+	got, err := fromBytes.UUID()
+	if err != nil || got != id {
+		t.Fatalf("scan bytes round trip: got %v, err %v", got, err)
+	}
+
+	// Drivers for dialects with a native uuid column (eg postgres via
+	// pgx) commonly scan into a string rather than raw bytes.
+	var fromString gormuuid.UUID
+	if err := fromString.Scan(id.String()); err != nil {
+		t.Fatalf("scan string: %v", err)
+	}
+	got, err = fromString.UUID()
+	if err != nil || got != id {
+		t.Fatalf("scan string round trip: got %v, err %v", got, err)
+	}
+}
+
+type taggedBaseModelThing struct {
+	gormuuid.BaseModel `gormuuid:"v7"`
+	Name               string
+}
+
+type taggedScopedThing struct {
+	gormuuid.ScopedUUID `gormuuid:"v7"`
+	Name                string
+}
+
+// TestGeneratorTagAppliesThroughEmbeddedTypes guards against generatorFromTag
+// only matching a field typed exactly gormuuid.UUID: BaseModel and ScopedUUID
+// both embed UUID one level down, and a `gormuuid:"..."` tag on them should
+// be just as effective as one on UUID itself.
+func TestGeneratorTagAppliesThroughEmbeddedTypes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&taggedBaseModelThing{}, &taggedScopedThing{}); err != nil {
+		t.Fatalf("automigrate: %v", err)
+	}
+
+	base := taggedBaseModelThing{Name: "thing"}
+	if err := db.Create(&base).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got, err := base.UUID.UUID(); err != nil || got.Version() != 7 {
+		t.Fatalf("expected a v7 ID via BaseModel's gormuuid tag, got version %v (err %v)", got.Version(), err)
+	}
+
+	// No scope in context, so ScopedUUID falls back to UUID's own
+	// generator, which should still honour the tag.
+	scoped := taggedScopedThing{Name: "thing"}
+	if err := db.Create(&scoped).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got, err := scoped.UUID.UUID(); err != nil || got.Version() != 7 {
+		t.Fatalf("expected a v7 ID via ScopedUUID's gormuuid tag, got version %v (err %v)", got.Version(), err)
+	}
+}
+
+type fakeDialector struct {
+	name string
+	gorm.Dialector
+}
+
+func (f fakeDialector) Name() string { return f.name }
+
+func TestUUIDGormValueUsesDialectNativeForm(t *testing.T) {
+	id := uuid.New()
+	raw, _ := id.MarshalBinary()
+	u := gormuuid.UUID{ID: raw}
+
+	for _, tt := range []struct {
+		dialect    string
+		wantString bool
+	}{
+		{"postgres", true},
+		{"sqlserver", true},
+		{"cockroachdb", true},
+		{"mysql", false},
+		{"sqlite", false},
+	} {
+		db := &gorm.DB{Config: &gorm.Config{Dialector: fakeDialector{name: tt.dialect}}}
+		expr := u.GormValue(nil, db)
+		if len(expr.Vars) != 1 {
+			t.Fatalf("%s: expected exactly one var, got %d", tt.dialect, len(expr.Vars))
+		}
+
+		_, isString := expr.Vars[0].(string)
+		if isString != tt.wantString {
+			t.Fatalf("%s: expected string=%v, got %T", tt.dialect, tt.wantString, expr.Vars[0])
+		}
+	}
+}